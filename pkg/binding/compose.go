@@ -0,0 +1,166 @@
+package binding
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Composer assembles the results of many binding Definitions, each keyed
+// by a structured output path (e.g. "cluster.nodes[0].host"), into a
+// single nested map[string]interface{} / []interface{} tree - the
+// projected binding file layout.
+type Composer struct {
+	root interface{}
+}
+
+// Set assigns value at outputPath, merging it into the tree built up by
+// previous calls. outputPath is a sequence of ".field" and "[index]"
+// tokens; missing slice indices are auto-grown with nil holes. Set errors
+// if outputPath is invalid, or if it conflicts with a value already
+// assigned by a previous call - for instance one caller assigning a
+// scalar to "cluster.name" and another assigning to "cluster.name.sub",
+// which needs "cluster.name" to be a map.
+func (c *Composer) Set(outputPath string, value interface{}) error {
+	tokens, err := parseOutputPath(outputPath)
+	if err != nil {
+		return err
+	}
+
+	root, err := setOutputPath(c.root, tokens, value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", outputPath, err)
+	}
+	c.root = root
+	return nil
+}
+
+// Tree returns the tree composed so far.
+func (c *Composer) Tree() interface{} {
+	return c.root
+}
+
+// JSON serializes the tree composed so far, for binding consumers that
+// opt into a single serialized blob instead of the projected file layout.
+func (c *Composer) JSON() ([]byte, error) {
+	return json.Marshal(c.root)
+}
+
+// DefinitionAt pairs a Definition with the structured output path its
+// result should be composed into. Key, when non-empty, names the field a
+// Definition wraps its result under (i.e. its own outputName) so that
+// field can be unwrapped into the bare leaf value before composing; leave
+// it empty for a Definition configured with an empty outputName, whose
+// Apply result is already the bare value (e.g. a stringOfMapDefinition
+// merged directly into its parent).
+type DefinitionAt struct {
+	Path       string
+	Key        string
+	Definition Definition
+}
+
+// ComposeFromDefinitions applies each entry's Definition against obj, in
+// order, and composes the results into a single tree via a Composer keyed
+// by its Path - the bridge between the per-field Definition resolution
+// binding annotations describe and the structured output path layout a
+// Composer assembles.
+func ComposeFromDefinitions(obj *unstructured.Unstructured, definitions []DefinitionAt) (interface{}, error) {
+	c := &Composer{}
+	for _, d := range definitions {
+		val, err := d.Definition.Apply(obj)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", d.Path, err)
+		}
+
+		result := val.Get()
+		if d.Key != "" {
+			m, ok := result.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: definition result is a %T, not a map keyed by %q", d.Path, result, d.Key)
+			}
+			leaf, ok := m[d.Key]
+			if !ok {
+				return nil, fmt.Errorf("%s: definition result has no %q key", d.Path, d.Key)
+			}
+			result = leaf
+		}
+
+		if err := c.Set(d.Path, result); err != nil {
+			return nil, err
+		}
+	}
+	return c.Tree(), nil
+}
+
+// setOutputPath deeply assigns value into root along tokens, growing
+// slices as needed, and returns the (possibly new) root.
+func setOutputPath(root interface{}, tokens []outputPathToken, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		if root != nil && !sameOutputShape(root, value) {
+			return nil, fmt.Errorf("conflicting assignment: existing value is a %T, new value is a %T", root, value)
+		}
+		return value, nil
+	}
+
+	token := tokens[0]
+	rest := tokens[1:]
+
+	if token.isIndex {
+		return setOutputPathIndex(root, token.index, rest, value)
+	}
+	return setOutputPathField(root, token.field, rest, value)
+}
+
+func setOutputPathIndex(root interface{}, index int, rest []outputPathToken, value interface{}) (interface{}, error) {
+	slice, ok := root.([]interface{})
+	if root != nil && !ok {
+		return nil, fmt.Errorf("cannot assign index %d: existing value is a %T, not a list", index, root)
+	}
+
+	for len(slice) <= index {
+		slice = append(slice, nil)
+	}
+
+	updated, err := setOutputPath(slice[index], rest, value)
+	if err != nil {
+		return nil, err
+	}
+	slice[index] = updated
+	return slice, nil
+}
+
+func setOutputPathField(root interface{}, field string, rest []outputPathToken, value interface{}) (interface{}, error) {
+	m, ok := root.(map[string]interface{})
+	if root != nil && !ok {
+		return nil, fmt.Errorf("cannot assign field %q: existing value is a %T, not a map", field, root)
+	}
+	if m == nil {
+		m = map[string]interface{}{}
+	}
+
+	updated, err := setOutputPath(m[field], rest, value)
+	if err != nil {
+		return nil, err
+	}
+	m[field] = updated
+	return m, nil
+}
+
+// sameOutputShape reports whether a and b are compatible for a direct
+// assignment at the same output path: both maps, both slices, or neither.
+func sameOutputShape(a, b interface{}) bool {
+	_, aIsMap := a.(map[string]interface{})
+	_, bIsMap := b.(map[string]interface{})
+	if aIsMap || bIsMap {
+		return aIsMap == bIsMap
+	}
+
+	_, aIsSlice := a.([]interface{})
+	_, bIsSlice := b.([]interface{})
+	if aIsSlice || bIsSlice {
+		return aIsSlice == bIsSlice
+	}
+
+	return true
+}
@@ -0,0 +1,214 @@
+package binding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseOutputPath(t *testing.T) {
+	type args struct {
+		description string
+		path        string
+		expected    []outputPathToken
+		expectError bool
+	}
+
+	testCases := []args{
+		{
+			description: "single field",
+			path:        "name",
+			expected:    []outputPathToken{{field: "name"}},
+		},
+		{
+			description: "nested fields",
+			path:        "cluster.name",
+			expected:    []outputPathToken{{field: "cluster"}, {field: "name"}},
+		},
+		{
+			description: "field with index",
+			path:        "cluster.nodes[0].host",
+			expected: []outputPathToken{
+				{field: "cluster"},
+				{field: "nodes"},
+				{isIndex: true, index: 0},
+				{field: "host"},
+			},
+		},
+		{
+			description: "empty path errors",
+			path:        "",
+			expectError: true,
+		},
+		{
+			description: "non-numeric index errors",
+			path:        "nodes[abc].host",
+			expectError: true,
+		},
+		{
+			description: "unterminated bracket errors",
+			path:        "nodes[0.host",
+			expectError: true,
+		},
+		{
+			description: "stray closing bracket errors",
+			path:        "nodes]0.host",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tokens, err := parseOutputPath(tc.path)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tokens)
+		})
+	}
+}
+
+func TestComposerDeepMerge(t *testing.T) {
+	c := &Composer{}
+
+	require.NoError(t, c.Set("cluster.nodes[0].host", "node-0.example.com"))
+	require.NoError(t, c.Set("cluster.nodes[1].host", "node-1.example.com"))
+	require.NoError(t, c.Set("cluster.name", "myapp"))
+
+	expected := map[string]interface{}{
+		"cluster": map[string]interface{}{
+			"name": "myapp",
+			"nodes": []interface{}{
+				map[string]interface{}{"host": "node-0.example.com"},
+				map[string]interface{}{"host": "node-1.example.com"},
+			},
+		},
+	}
+	require.Equal(t, expected, c.Tree())
+}
+
+func TestComposerIndexGapsAutoGrowWithNilHoles(t *testing.T) {
+	c := &Composer{}
+
+	require.NoError(t, c.Set("nodes[2].host", "node-2.example.com"))
+
+	expected := map[string]interface{}{
+		"nodes": []interface{}{
+			nil,
+			nil,
+			map[string]interface{}{"host": "node-2.example.com"},
+		},
+	}
+	require.Equal(t, expected, c.Tree())
+}
+
+func TestComposerConflictingScalarVsMap(t *testing.T) {
+	t.Run("scalar then map at the same path", func(t *testing.T) {
+		c := &Composer{}
+		require.NoError(t, c.Set("cluster.name", "myapp"))
+		err := c.Set("cluster.name.sub", "oops")
+		require.Error(t, err)
+	})
+
+	t.Run("map then scalar at the same path", func(t *testing.T) {
+		c := &Composer{}
+		require.NoError(t, c.Set("cluster.name.sub", "oops"))
+		err := c.Set("cluster.name", "myapp")
+		require.Error(t, err)
+	})
+
+	t.Run("index then field at the same path", func(t *testing.T) {
+		c := &Composer{}
+		require.NoError(t, c.Set("nodes[0]", "node-0.example.com"))
+		err := c.Set("nodes.name", "myapp")
+		require.Error(t, err)
+	})
+}
+
+func TestComposeFromDefinitions(t *testing.T) {
+	u := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name": "myapp",
+			},
+			"status": map[string]interface{}{
+				"nodes": []interface{}{
+					map[string]interface{}{"address": "node-0.example.com"},
+					map[string]interface{}{"address": "node-1.example.com"},
+				},
+			},
+		},
+	}
+
+	definitions := []DefinitionAt{
+		{
+			Path: "cluster.name",
+			Key:  "name",
+			Definition: &stringDefinition{
+				outputName: "name",
+				definition: definition{path: "{.metadata.name}"},
+			},
+		},
+		{
+			Path: "cluster.nodes[0].host",
+			Key:  "host",
+			Definition: &stringDefinition{
+				outputName: "host",
+				definition: definition{path: "pointer:/status/nodes/0/address"},
+			},
+		},
+		{
+			Path: "cluster.nodes[1].host",
+			Key:  "host",
+			Definition: &stringDefinition{
+				outputName: "host",
+				definition: definition{path: "pointer:/status/nodes/1/address"},
+			},
+		},
+	}
+
+	tree, err := ComposeFromDefinitions(u, definitions)
+	require.NoError(t, err)
+
+	expected := map[string]interface{}{
+		"cluster": map[string]interface{}{
+			"name": "myapp",
+			"nodes": []interface{}{
+				map[string]interface{}{"host": "node-0.example.com"},
+				map[string]interface{}{"host": "node-1.example.com"},
+			},
+		},
+	}
+	require.Equal(t, expected, tree)
+}
+
+func TestComposeFromDefinitionsErrorIncludesPath(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+
+	definitions := []DefinitionAt{
+		{
+			Path: "cluster.name",
+			Key:  "name",
+			Definition: &stringDefinition{
+				outputName: "name",
+				definition: definition{path: "{.metadata.name}"},
+			},
+		},
+	}
+
+	_, err := ComposeFromDefinitions(u, definitions)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cluster.name")
+}
+
+func TestComposerJSON(t *testing.T) {
+	c := &Composer{}
+	require.NoError(t, c.Set("cluster.name", "myapp"))
+
+	b, err := c.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"cluster":{"name":"myapp"}}`, string(b))
+}
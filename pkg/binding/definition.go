@@ -0,0 +1,281 @@
+package binding
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Definition knows how to extract a value out of a source object and
+// project it into the shape a binding output expects.
+type Definition interface {
+	// Apply resolves the Definition against obj and returns the resulting
+	// Value.
+	Apply(obj *unstructured.Unstructured) (Value, error)
+}
+
+// definition carries the fields every concrete Definition needs in order to
+// locate the data it is responsible for.
+type definition struct {
+	// path is a raw annotation path expression, e.g.
+	// "{.status.dbCredentials.username}" or a literal string embedding one
+	// or more JSONPath expressions such as "foo-{.status.dbCredentials.username}".
+	path string
+	// transforms is the chain of post-processing steps, parsed from a
+	// transform= annotation clause, applied to the resolved value before a
+	// Definition projects it into its final shape.
+	transforms []Transform
+}
+
+// applyTransforms runs v through d.transforms, in order.
+func (d *definition) applyTransforms(v interface{}) (interface{}, error) {
+	return applyTransforms(v, d.transforms)
+}
+
+// jsonPathExpr matches a single {...} JSONPath expression embedded in a
+// definition's path.
+var jsonPathExpr = regexp.MustCompile(`\{[^}]+\}`)
+
+// resolve evaluates d.path against obj. A path prefixed with
+// jsonPointerPrefix is resolved as an RFC 6901 JSON Pointer instead of a
+// JSONPath expression. Otherwise, when the path is made up of a single
+// JSONPath expression and nothing else, the raw resolved value is returned
+// (which may be a map or a slice). Otherwise every embedded JSONPath
+// expression is resolved, stringified, and substituted back into the
+// surrounding literal text.
+func (d *definition) resolve(obj *unstructured.Unstructured) (interface{}, error) {
+	if strings.HasPrefix(d.path, jsonPointerPrefix) {
+		return resolveJSONPointer(strings.TrimPrefix(d.path, jsonPointerPrefix), obj.Object)
+	}
+	return resolvePath(d.path, obj.Object)
+}
+
+func resolvePath(path string, obj interface{}) (interface{}, error) {
+	matches := jsonPathExpr.FindAllString(path, -1)
+	if len(matches) == 0 {
+		return path, nil
+	}
+
+	if len(matches) == 1 && matches[0] == path {
+		return evalJSONPath(matches[0], obj)
+	}
+
+	result := path
+	for _, expr := range matches {
+		resolved, err := evalJSONPath(expr, obj)
+		if err != nil {
+			return nil, err
+		}
+		s, err := stringify(resolved)
+		if err != nil {
+			return nil, err
+		}
+		result = strings.Replace(result, expr, s, 1)
+	}
+	return result, nil
+}
+
+func evalJSONPath(expr string, obj interface{}) (interface{}, error) {
+	jp := jsonpath.New("definition")
+	if err := jp.Parse(expr); err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", expr, err)
+	}
+	results, err := jp.FindResults(obj)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve path %q: %w", expr, err)
+	}
+	if len(results) == 0 || len(results[0]) == 0 {
+		return nil, fmt.Errorf("path %q did not resolve to a value", expr)
+	}
+	return results[0][0].Interface(), nil
+}
+
+// stringify renders a resolved path leaf as a string, for embedding back
+// into the surrounding literal text (or as the leaf value itself). bool,
+// float64, int and json.Number are all common shapes for scalars found in
+// a CR's status, so they're coerced to their canonical string form rather
+// than treated as an error.
+func stringify(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case float64:
+		// Status fields decoded from JSON surface as float64 even when the
+		// underlying value is an integer; truncate rather than keep a
+		// trailing ".0" (or a fractional part the caller can't use as a
+		// string).
+		return strconv.Itoa(int(t)), nil
+	case fmt.Stringer:
+		return t.String(), nil
+	case map[string]interface{}, []interface{}:
+		return "", fmt.Errorf("value %v cannot be converted to a string", t)
+	default:
+		return fmt.Sprintf("%v", t), nil
+	}
+}
+
+// stringDefinition resolves to a single string value, optionally a literal
+// raw value instead of a path, keyed under outputName.
+type stringDefinition struct {
+	outputName string
+	definition definition
+	// value is a literal value to use instead of resolving definition.path.
+	value string
+}
+
+func (d *stringDefinition) Apply(obj *unstructured.Unstructured) (Value, error) {
+	var resolved interface{}
+	if d.value != "" {
+		resolved = d.value
+	} else {
+		var err error
+		resolved, err = d.definition.resolve(obj)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resolved, err := d.definition.applyTransforms(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := stringify(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &value{v: map[string]interface{}{
+		d.outputName: s,
+	}}, nil
+}
+
+// stringOfMapDefinition resolves a path to a map value, optionally wrapping
+// it under outputName. When outputName is empty the resolved map is
+// returned unwrapped, so its keys are merged directly into the binding.
+type stringOfMapDefinition struct {
+	outputName string
+	definition definition
+}
+
+func (d *stringOfMapDefinition) Apply(obj *unstructured.Unstructured) (Value, error) {
+	resolved, err := d.definition.resolve(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err = d.definition.applyTransforms(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := resolved.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("path %q did not resolve to a map", d.definition.path)
+	}
+
+	if d.outputName == "" {
+		return &value{v: m}, nil
+	}
+
+	return &value{v: map[string]interface{}{
+		d.outputName: m,
+	}}, nil
+}
+
+// sliceOfStringsFromPathDefinition resolves a path to a slice of maps and
+// projects sourceValue out of each entry, producing a slice of strings
+// keyed under outputName.
+type sliceOfStringsFromPathDefinition struct {
+	sourceValue string
+	definition  definition
+	outputName  string
+}
+
+func (d *sliceOfStringsFromPathDefinition) Apply(obj *unstructured.Unstructured) (Value, error) {
+	items, err := d.resolveItems(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		v, ok := item[d.sourceValue]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in %q entry", d.sourceValue, d.definition.path)
+		}
+		values = append(values, v)
+	}
+
+	return &value{v: map[string]interface{}{
+		d.outputName: values,
+	}}, nil
+}
+
+func (d *sliceOfStringsFromPathDefinition) resolveItems(obj *unstructured.Unstructured) ([]map[string]interface{}, error) {
+	return resolveSliceOfMaps(&d.definition, obj)
+}
+
+// sliceOfMapsFromPathDefinition resolves a path to a slice of maps and
+// builds a map keyed by sourceKey, valued by sourceValue, for each entry.
+type sliceOfMapsFromPathDefinition struct {
+	sourceKey   string
+	sourceValue string
+	outputName  string
+	definition  definition
+}
+
+func (d *sliceOfMapsFromPathDefinition) Apply(obj *unstructured.Unstructured) (Value, error) {
+	items, err := resolveSliceOfMaps(&d.definition, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]interface{}, len(items))
+	for _, item := range items {
+		k, ok := item[d.sourceKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in %q entry", d.sourceKey, d.definition.path)
+		}
+		v, ok := item[d.sourceValue]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in %q entry", d.sourceValue, d.definition.path)
+		}
+		out[k] = v
+	}
+
+	return &value{v: map[string]interface{}{
+		d.outputName: out,
+	}}, nil
+}
+
+func resolveSliceOfMaps(d *definition, obj *unstructured.Unstructured) ([]map[string]interface{}, error) {
+	resolved, err := d.resolve(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err = d.applyTransforms(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	slice, ok := resolved.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("path %q did not resolve to a slice", d.path)
+	}
+
+	items := make([]map[string]interface{}, 0, len(slice))
+	for _, elem := range slice {
+		m, ok := elem.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("entry in %q is not a map", d.path)
+		}
+		items = append(items, m)
+	}
+	return items, nil
+}
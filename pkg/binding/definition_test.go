@@ -1,6 +1,10 @@
 package binding
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/redhat-developer/service-binding-operator/test/mocks"
@@ -8,6 +12,25 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// fakeExternalSecretReader is a fake externalSecretReader for exercising
+// mapFromDataFieldDefinition against an external provider without a real
+// backend, mirroring the secretConfigMapReader mocks used below.
+type fakeExternalSecretReader struct {
+	wantMountPath string
+	wantPath      string
+	data          map[string]string
+}
+
+func (f *fakeExternalSecretReader) Read(_ context.Context, mountPath, path string) (map[string]string, error) {
+	if f.wantMountPath != "" && mountPath != f.wantMountPath {
+		return nil, fmt.Errorf("unexpected mountPath %q, want %q", mountPath, f.wantMountPath)
+	}
+	if f.wantPath != "" && path != f.wantPath {
+		return nil, fmt.Errorf("unexpected path %q, want %q", path, f.wantPath)
+	}
+	return f.data, nil
+}
+
 func TestStringDefinition(t *testing.T) {
 	type args struct {
 		description   string
@@ -58,6 +81,54 @@ func TestStringDefinition(t *testing.T) {
 				"foo": "AzureDiamond-foo",
 			},
 		},
+		{
+			description: "json pointer, nested traversal",
+			outputName:  "username",
+			path:        "pointer:/status/dbCredentials/username",
+			expectedValue: map[string]interface{}{
+				"username": "AzureDiamond",
+			},
+		},
+		{
+			description: "json pointer, escaped token",
+			outputName:  "value",
+			path:        "pointer:/status/weird~1key",
+			expectedValue: map[string]interface{}{
+				"value": "AzureDiamond",
+			},
+		},
+		{
+			description: "json pointer, array index",
+			outputName:  "host",
+			path:        "pointer:/status/nodes/1/host",
+			expectedValue: map[string]interface{}{
+				"host": "node-1.example.com",
+			},
+		},
+		{
+			description: "scalar coercion, bool",
+			outputName:  "ready",
+			path:        "{.status.ready}",
+			expectedValue: map[string]interface{}{
+				"ready": "true",
+			},
+		},
+		{
+			description: "scalar coercion, float truncated to int",
+			outputName:  "replicas",
+			path:        "{.status.replicas}",
+			expectedValue: map[string]interface{}{
+				"replicas": "7",
+			},
+		},
+		{
+			description: "scalar coercion, json.Number",
+			outputName:  "offset",
+			path:        "{.status.offset}",
+			expectedValue: map[string]interface{}{
+				"offset": "-12345",
+			},
+		},
 	}
 
 	u := &unstructured.Unstructured{
@@ -67,6 +138,14 @@ func TestStringDefinition(t *testing.T) {
 					"username": "AzureDiamond",
 					"password": "foo",
 				},
+				"weird/key": "AzureDiamond",
+				"nodes": []interface{}{
+					map[string]interface{}{"host": "node-0.example.com"},
+					map[string]interface{}{"host": "node-1.example.com"},
+				},
+				"ready":    true,
+				"replicas": 7.9,
+				"offset":   json.Number("-12345"),
 			},
 		},
 	}
@@ -87,6 +166,25 @@ func TestStringDefinition(t *testing.T) {
 	}
 }
 
+func TestStringDefinitionMapLeafErrors(t *testing.T) {
+	d := &stringDefinition{
+		outputName: "dbCredentials",
+		definition: definition{
+			path: "{.status.dbCredentials}",
+		},
+	}
+	_, err := d.Apply(&unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"dbCredentials": map[string]interface{}{
+					"username": "AzureDiamond",
+				},
+			},
+		},
+	})
+	require.Error(t, err)
+}
+
 func TestStringOfMap(t *testing.T) {
 	type args struct {
 		description   string
@@ -344,3 +442,257 @@ func TestMapFromConfigMapDataFieldWithOutputNameAndSourceValue(t *testing.T) {
 	}
 	require.Equal(t, v, val.Get())
 }
+
+func TestMapFromSecretDataFieldWithTransform(t *testing.T) {
+	f := mocks.NewFake(t, "test-namespace")
+	f.AddMockedUnstructuredSecret("dbCredentials-secret")
+
+	transforms, err := parseTransforms("base64encode")
+	require.NoError(t, err)
+
+	d := &mapFromDataFieldDefinition{
+		secretConfigMapReader: &secretConfigMapReader{
+			secretReader:    secretsReader(f.FakeDynClient()),
+			configMapReader: configMapsReader(f.FakeDynClient()),
+		},
+		objectType: secretObjectType,
+		definition: definition{
+			path:       "{.status.dbCredentials}",
+			transforms: transforms,
+		},
+	}
+	val, err := d.Apply(&unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"namespace": "test-namespace",
+			},
+			"status": map[string]interface{}{
+				"dbCredentials": "dbCredentials-secret",
+			},
+		},
+	})
+	require.NoError(t, err)
+	// The transform is applied to each key's value independently, not to
+	// the map as a whole - data's keys are unrelated Secret fields, not
+	// parts of a single structured value.
+	v := map[string]string{
+		"username": base64.StdEncoding.EncodeToString([]byte("user")),
+		"password": base64.StdEncoding.EncodeToString([]byte("password")),
+	}
+	require.Equal(t, v, val.Get())
+}
+
+func TestMapFromExternalSecretStore(t *testing.T) {
+	d := &mapFromDataFieldDefinition{
+		externalSecretReader: &fakeExternalSecretReader{
+			wantMountPath: "secret/data/myapp",
+			wantPath:      "myapp/credentials",
+			data: map[string]string{
+				"username": "user",
+				"password": "password",
+			},
+		},
+		objectType: vaultObjectType,
+		mountPath:  "secret/data/myapp",
+		definition: definition{
+			path: "{.status.credentialsRef}",
+		},
+	}
+	val, err := d.Apply(&unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"namespace": "test-namespace",
+			},
+			"status": map[string]interface{}{
+				"credentialsRef": "myapp/credentials",
+			},
+		},
+	})
+	require.NoError(t, err)
+	v := map[string]string{
+		"username": "user",
+		"password": "password",
+	}
+	require.Equal(t, v, val.Get())
+}
+
+func TestMapFromExternalSecretStoreWithSourceValue(t *testing.T) {
+	d := &mapFromDataFieldDefinition{
+		externalSecretReader: &fakeExternalSecretReader{
+			data: map[string]string{
+				"username": "user",
+				"password": "password",
+			},
+		},
+		objectType:  awsSecretsManagerObjectType,
+		sourceValue: "password",
+		outputName:  "dbPassword",
+		definition: definition{
+			path: "{.status.credentialsRef}",
+		},
+	}
+	val, err := d.Apply(&unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"namespace": "test-namespace",
+			},
+			"status": map[string]interface{}{
+				"credentialsRef": "myapp/credentials",
+			},
+		},
+	})
+	require.NoError(t, err)
+	v := map[string]string{
+		"dbPassword": "password",
+	}
+	require.Equal(t, v, val.Get())
+}
+
+func TestDefinitionTransforms(t *testing.T) {
+	payloadJSON := `{"username":"AzureDiamond","password":"hunter2"}`
+	rawEncoded := base64.StdEncoding.EncodeToString([]byte("hunter2"))
+	payloadEncoded := base64.StdEncoding.EncodeToString([]byte(payloadJSON))
+
+	u := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"endpoint": map[string]interface{}{
+					"host": "db.example.com",
+					"port": "5432",
+					"db":   "myapp",
+				},
+				"encoded":        rawEncoded,
+				"payload":        payloadJSON,
+				"encodedPayload": payloadEncoded,
+				"raw":            "hello world",
+			},
+		},
+	}
+
+	t.Run("template transform renders a map output into a scalar", func(t *testing.T) {
+		transforms, err := parseTransforms(`template:"jdbc:postgresql://{{.host}}:{{.port}}/{{.db}}"`)
+		require.NoError(t, err)
+
+		d := &stringDefinition{
+			outputName: "jdbcUrl",
+			definition: definition{
+				path:       "{.status.endpoint}",
+				transforms: transforms,
+			},
+		}
+		val, err := d.Apply(u)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"jdbcUrl": "jdbc:postgresql://db.example.com:5432/myapp",
+		}, val.Get())
+	})
+
+	t.Run("base64decode transform on a scalar output", func(t *testing.T) {
+		transforms, err := parseTransforms("base64decode")
+		require.NoError(t, err)
+
+		d := &stringDefinition{
+			outputName: "password",
+			definition: definition{
+				path:       "{.status.encoded}",
+				transforms: transforms,
+			},
+		}
+		val, err := d.Apply(u)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"password": "hunter2",
+		}, val.Get())
+	})
+
+	t.Run("base64encode transform on a scalar output", func(t *testing.T) {
+		transforms, err := parseTransforms("base64encode")
+		require.NoError(t, err)
+
+		d := &stringDefinition{
+			outputName: "encoded",
+			definition: definition{
+				path:       "{.status.raw}",
+				transforms: transforms,
+			},
+		}
+		val, err := d.Apply(u)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"encoded": base64.StdEncoding.EncodeToString([]byte("hello world")),
+		}, val.Get())
+	})
+
+	t.Run("jsonparse transform on a map output", func(t *testing.T) {
+		transforms, err := parseTransforms("jsonparse")
+		require.NoError(t, err)
+
+		d := &stringOfMapDefinition{
+			definition: definition{
+				path:       "{.status.payload}",
+				transforms: transforms,
+			},
+		}
+		val, err := d.Apply(u)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"username": "AzureDiamond",
+			"password": "hunter2",
+		}, val.Get())
+	})
+
+	t.Run("regexp transform on a scalar output", func(t *testing.T) {
+		transforms, err := parseTransforms("regexp:world:Gophers")
+		require.NoError(t, err)
+
+		d := &stringDefinition{
+			outputName: "greeting",
+			definition: definition{
+				path:       "{.status.raw}",
+				transforms: transforms,
+			},
+		}
+		val, err := d.Apply(u)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"greeting": "hello Gophers",
+		}, val.Get())
+	})
+
+	t.Run("chained transforms", func(t *testing.T) {
+		transforms, err := parseTransforms("base64decode|jsonparse")
+		require.NoError(t, err)
+
+		d := &stringOfMapDefinition{
+			definition: definition{
+				path:       "{.status.encodedPayload}",
+				transforms: transforms,
+			},
+		}
+		val, err := d.Apply(u)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"username": "AzureDiamond",
+			"password": "hunter2",
+		}, val.Get())
+	})
+
+	t.Run("transform incompatible with value shape errors", func(t *testing.T) {
+		transforms, err := parseTransforms("base64decode")
+		require.NoError(t, err)
+
+		d := &stringOfMapDefinition{
+			definition: definition{
+				path:       "{.status.endpoint}",
+				transforms: transforms,
+			},
+		}
+		_, err = d.Apply(u)
+		require.Error(t, err)
+	})
+
+	t.Run("unknown transform name errors", func(t *testing.T) {
+		_, err := parseTransforms("nope")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,59 @@
+package binding
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// outputPathToken is one step of a structured output path: either a field
+// name (as in ".field") or a slice index (as in "[index]").
+type outputPathToken struct {
+	field   string
+	index   int
+	isIndex bool
+}
+
+// parseOutputPath parses a Pulumi-style structured output path, such as
+// "cluster.nodes[0].host", into the sequence of field/index steps needed
+// to assign a value at that location in a composed tree. It is a hand
+// written scanner rather than a single regexp so that malformed bracket
+// expressions (an unterminated "[", a stray "]", or a non-numeric index
+// like "nodes[abc]") are rejected instead of silently falling back to
+// being parsed as field names.
+func parseOutputPath(path string) ([]outputPathToken, error) {
+	if path == "" {
+		return nil, fmt.Errorf("output path must not be empty")
+	}
+
+	var tokens []outputPathToken
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			i++
+		case '[':
+			end := i + 1
+			for end < len(path) && path[end] != ']' {
+				end++
+			}
+			if end >= len(path) {
+				return nil, fmt.Errorf("invalid output path %q: unterminated %q", path, "[")
+			}
+			digits := path[i+1 : end]
+			idx, err := strconv.Atoi(digits)
+			if digits == "" || err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid output path %q: %q is not a valid index", path, digits)
+			}
+			tokens = append(tokens, outputPathToken{isIndex: true, index: idx})
+			i = end + 1
+		case ']':
+			return nil, fmt.Errorf("invalid output path %q: unexpected %q", path, "]")
+		default:
+			start := i
+			for i < len(path) && path[i] != '.' && path[i] != '[' && path[i] != ']' {
+				i++
+			}
+			tokens = append(tokens, outputPathToken{field: path[start:i]})
+		}
+	}
+	return tokens, nil
+}
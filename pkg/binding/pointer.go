@@ -0,0 +1,53 @@
+package binding
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPointerPrefix marks a definition's path as an RFC 6901 JSON Pointer
+// rather than a JSONPath expression, e.g.
+// "pointer:/status/dbCredentials/username".
+const jsonPointerPrefix = "pointer:"
+
+// resolveJSONPointer walks obj following the RFC 6901 JSON Pointer
+// pointer, descending into maps by key and into slices by index.
+func resolveJSONPointer(pointer string, obj interface{}) (interface{}, error) {
+	tokens := strings.Split(pointer, "/")
+	if len(tokens) > 0 && tokens[0] == "" {
+		tokens = tokens[1:]
+	}
+
+	current := obj
+	for _, token := range tokens {
+		token = unescapePointerToken(token)
+
+		switch c := current.(type) {
+		case map[string]interface{}:
+			v, ok := c[token]
+			if !ok {
+				return nil, fmt.Errorf("pointer %q: key %q not found", pointer, token)
+			}
+			current = v
+		case []interface{}:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(c) {
+				return nil, fmt.Errorf("pointer %q: %q is not a valid index", pointer, token)
+			}
+			current = c[idx]
+		default:
+			return nil, fmt.Errorf("pointer %q: cannot descend into %T at %q", pointer, current, token)
+		}
+	}
+	return current, nil
+}
+
+// unescapePointerToken undoes RFC 6901's "~1" -> "/" and "~0" -> "~"
+// escaping of a single reference token. Order matters: "~01" must decode
+// to "~1", which only happens when "~1" is unescaped before "~0".
+func unescapePointerToken(token string) string {
+	token = strings.ReplaceAll(token, "~1", "/")
+	token = strings.ReplaceAll(token, "~0", "~")
+	return token
+}
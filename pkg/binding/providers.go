@@ -0,0 +1,43 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/binding/providers"
+	_ "github.com/redhat-developer/service-binding-operator/pkg/binding/providers/awssecretsmanager"
+	_ "github.com/redhat-developer/service-binding-operator/pkg/binding/providers/azurekeyvault"
+	_ "github.com/redhat-developer/service-binding-operator/pkg/binding/providers/gcpsecretmanager"
+	_ "github.com/redhat-developer/service-binding-operator/pkg/binding/providers/vault"
+)
+
+// externalSecretReader fetches key/value data out of a secret store that
+// lives outside the cluster, given the mount/path coordinates carried by a
+// binding annotation. Backends register themselves with the providers
+// package; see pkg/binding/providers/{vault,awssecretsmanager,azurekeyvault,gcpsecretmanager}.
+type externalSecretReader interface {
+	Read(ctx context.Context, mountPath, path string) (map[string]string, error)
+}
+
+// providerCredentialsSecretName is the well-known Secret, in the
+// operator's own namespace, that external secret provider backends read
+// their connection credentials from.
+const providerCredentialsSecretName = "service-binding-external-secret-providers"
+
+// externalSecretReaderFor builds the externalSecretReader for backend,
+// using the credentials stored in providerCredentialsSecretName in
+// operatorNamespace.
+func externalSecretReaderFor(client dynamic.Interface, operatorNamespace string, backend objectType) (externalSecretReader, error) {
+	credentials, err := secretsReader(client)(operatorNamespace, providerCredentialsSecretName)
+	if err != nil {
+		return nil, fmt.Errorf("could not read credentials for external secret provider %q: %w", backend, err)
+	}
+
+	reader, err := providers.New(string(backend), credentials)
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
@@ -0,0 +1,67 @@
+// Package awssecretsmanager is an external secret provider backend for AWS
+// Secrets Manager.
+package awssecretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/binding/providers"
+)
+
+func init() {
+	providers.Register("AWSSecretsManager", newReader)
+}
+
+// secretsManagerClient is the subset of *secretsmanager.Client reader
+// depends on, narrowed out so tests can supply a fake in place of a real
+// AWS Secrets Manager client.
+type secretsManagerClient interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+type reader struct {
+	client secretsManagerClient
+}
+
+// newReader builds an AWS Secrets Manager client from creds, which is
+// expected to carry "region", "accessKeyID", "secretAccessKey" and
+// optionally "sessionToken" keys.
+func newReader(creds map[string]string) (providers.Reader, error) {
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion(creds["region"]),
+		config.WithCredentialsProvider(awsCredentials(creds)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not build AWS config: %w", err)
+	}
+	return &reader{client: secretsmanager.NewFromConfig(cfg)}, nil
+}
+
+func awsCredentials(creds map[string]string) aws.CredentialsProvider {
+	return credentials.NewStaticCredentialsProvider(creds["accessKeyID"], creds["secretAccessKey"], creds["sessionToken"])
+}
+
+// Read fetches the secret named path from AWS Secrets Manager. mountPath
+// is unused by this backend. The secret's value is expected to be a JSON
+// object of strings.
+func (r *reader) Read(ctx context.Context, mountPath, path string) (map[string]string, error) {
+	out, err := r.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(path),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read AWS secret %q: %w", path, err)
+	}
+
+	data := map[string]string{}
+	if err := json.Unmarshal([]byte(aws.ToString(out.SecretString)), &data); err != nil {
+		return nil, fmt.Errorf("secret %q is not a JSON object of strings: %w", path, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,49 @@
+package awssecretsmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSecretsManagerClient struct {
+	wantSecretID string
+	secretString string
+	err          error
+}
+
+func (f *fakeSecretsManagerClient) GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.wantSecretID != "" && aws.ToString(params.SecretId) != f.wantSecretID {
+		return nil, nil
+	}
+	return &secretsmanager.GetSecretValueOutput{
+		SecretString: aws.String(f.secretString),
+	}, nil
+}
+
+func TestReadParsesJSONObject(t *testing.T) {
+	r := &reader{client: &fakeSecretsManagerClient{
+		wantSecretID: "dbCredentials",
+		secretString: `{"username":"AzureDiamond","password":"hunter2"}`,
+	}}
+
+	data, err := r.Read(context.Background(), "", "dbCredentials")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"username": "AzureDiamond",
+		"password": "hunter2",
+	}, data)
+}
+
+func TestReadErrorsOnNonJSONSecret(t *testing.T) {
+	r := &reader{client: &fakeSecretsManagerClient{secretString: "not json"}}
+
+	_, err := r.Read(context.Background(), "", "dbCredentials")
+	require.Error(t, err)
+}
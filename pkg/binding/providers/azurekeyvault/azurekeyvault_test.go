@@ -0,0 +1,47 @@
+package azurekeyvault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/binding/providers"
+)
+
+type fakeKeyVaultClient struct {
+	wantName    string
+	value       string
+	returnEmpty bool
+	err         error
+}
+
+func (f *fakeKeyVaultClient) GetSecret(ctx context.Context, name, version string, options *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error) {
+	if f.err != nil {
+		return azsecrets.GetSecretResponse{}, f.err
+	}
+	if f.wantName != "" && name != f.wantName {
+		return azsecrets.GetSecretResponse{}, nil
+	}
+	resp := azsecrets.GetSecretResponse{}
+	if !f.returnEmpty {
+		resp.Value = &f.value
+	}
+	return resp, nil
+}
+
+func TestReadKeysSingleValue(t *testing.T) {
+	r := &reader{client: &fakeKeyVaultClient{wantName: "db-password", value: "hunter2"}}
+
+	data, err := r.Read(context.Background(), "", "db-password")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{providers.SingleValueKey: "hunter2"}, data)
+}
+
+func TestReadErrorsOnMissingValue(t *testing.T) {
+	r := &reader{client: &fakeKeyVaultClient{returnEmpty: true}}
+
+	_, err := r.Read(context.Background(), "", "db-password")
+	require.Error(t, err)
+}
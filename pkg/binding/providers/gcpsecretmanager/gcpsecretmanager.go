@@ -0,0 +1,56 @@
+// Package gcpsecretmanager is an external secret provider backend for
+// Google Cloud Secret Manager.
+package gcpsecretmanager
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/option"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/binding/providers"
+)
+
+func init() {
+	providers.Register("GCPSecretManager", newReader)
+}
+
+// secretManagerClient is the subset of *secretmanager.Client reader
+// depends on, narrowed out so tests can supply a fake in place of a real
+// Secret Manager client.
+type secretManagerClient interface {
+	AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error)
+}
+
+type reader struct {
+	client secretManagerClient
+}
+
+// newReader builds a GCP Secret Manager client from credentials, which is
+// expected to carry a "serviceAccountJSON" key holding the service
+// account's JSON key file.
+func newReader(credentials map[string]string) (providers.Reader, error) {
+	client, err := secretmanager.NewClient(context.Background(),
+		option.WithCredentialsJSON([]byte(credentials["serviceAccountJSON"])))
+	if err != nil {
+		return nil, fmt.Errorf("could not build GCP secret manager client: %w", err)
+	}
+	return &reader{client: client}, nil
+}
+
+// Read fetches the latest version of the secret named path from GCP
+// Secret Manager. mountPath is unused by this backend. GCP secrets hold a
+// single opaque payload rather than a structured document, so the
+// returned map has a single entry keyed by providers.SingleValueKey.
+func (r *reader) Read(ctx context.Context, mountPath, path string) (map[string]string, error) {
+	resp, err := r.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: path + "/versions/latest",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read GCP secret %q: %w", path, err)
+	}
+	return map[string]string{providers.SingleValueKey: string(resp.Payload.Data)}, nil
+}
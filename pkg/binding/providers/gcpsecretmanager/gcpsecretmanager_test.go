@@ -0,0 +1,48 @@
+package gcpsecretmanager
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/binding/providers"
+)
+
+type fakeSecretManagerClient struct {
+	wantName string
+	payload  []byte
+	err      error
+}
+
+func (f *fakeSecretManagerClient) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.wantName != "" && req.Name != f.wantName {
+		return nil, nil
+	}
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Payload: &secretmanagerpb.SecretPayload{Data: f.payload},
+	}, nil
+}
+
+func TestReadKeysSingleValue(t *testing.T) {
+	r := &reader{client: &fakeSecretManagerClient{
+		wantName: "projects/my-project/secrets/db-password/versions/latest",
+		payload:  []byte("hunter2"),
+	}}
+
+	data, err := r.Read(context.Background(), "", "projects/my-project/secrets/db-password")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{providers.SingleValueKey: "hunter2"}, data)
+}
+
+func TestReadPropagatesError(t *testing.T) {
+	r := &reader{client: &fakeSecretManagerClient{err: context.DeadlineExceeded}}
+
+	_, err := r.Read(context.Background(), "", "projects/my-project/secrets/db-password")
+	require.Error(t, err)
+}
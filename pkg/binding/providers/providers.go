@@ -0,0 +1,49 @@
+// Package providers is the registry of pluggable external secret store
+// backends (Vault, AWS Secrets Manager, Azure Key Vault, GCP Secret
+// Manager, ...) that a binding definition can resolve a path against
+// instead of an in-cluster Secret or ConfigMap.
+//
+// A backend registers itself from its own package's init function by
+// calling Register; pkg/binding blank-imports every known backend package
+// so that registration happens as a side effect of importing pkg/binding.
+package providers
+
+import (
+	"context"
+	"fmt"
+)
+
+// Reader fetches key/value data out of an external secret store. mountPath
+// identifies where in the backend to look (e.g. a Vault KV mount); path
+// identifies the secret within it.
+type Reader interface {
+	Read(ctx context.Context, mountPath, path string) (map[string]string, error)
+}
+
+// SingleValueKey is the key a backend whose secrets hold a single opaque
+// value (rather than a structured key/value document) should use for that
+// value's entry in the map Read returns, so that callers configuring a
+// binding's sourceValue (or relying on the no-sourceValue single-key
+// default) have a predictable name to reference.
+const SingleValueKey = "value"
+
+// Factory builds a Reader for a backend, given the operator's configured
+// credentials for that backend.
+type Factory func(credentials map[string]string) (Reader, error)
+
+var registry = map[string]Factory{}
+
+// Register makes a backend's Factory available under name (e.g. "Vault").
+// It is meant to be called from the init function of a backend package.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New builds a Reader for the backend registered under name.
+func New(name string, credentials map[string]string) (Reader, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("no external secret provider registered for object type %q", name)
+	}
+	return factory(credentials)
+}
@@ -0,0 +1,68 @@
+// Package vault is an external secret provider backend for HashiCorp
+// Vault, supporting both the KV v1 and KV v2 secrets engines.
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/binding/providers"
+)
+
+func init() {
+	providers.Register("Vault", newReader)
+}
+
+type reader struct {
+	client *vaultapi.Client
+}
+
+// newReader builds a Vault client from credentials, which is expected to
+// carry "address" and "token" keys.
+func newReader(credentials map[string]string) (providers.Reader, error) {
+	cfg := vaultapi.DefaultConfig()
+	if address := credentials["address"]; address != "" {
+		cfg.Address = address
+	}
+
+	client, err := vaultapi.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not build vault client: %w", err)
+	}
+	client.SetToken(credentials["token"])
+
+	return &reader{client: client}, nil
+}
+
+// Read fetches the secret at mountPath/path from Vault. KV v2 nests the
+// actual keys one level deeper, under a "data" key alongside "metadata";
+// that shape is unwrapped transparently.
+func (r *reader) Read(ctx context.Context, mountPath, path string) (map[string]string, error) {
+	full := strings.TrimSuffix(mountPath, "/") + "/" + strings.TrimPrefix(path, "/")
+
+	secret, err := r.client.Logical().ReadWithContext(ctx, full)
+	if err != nil {
+		return nil, fmt.Errorf("could not read vault secret %q: %w", full, err)
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("vault secret %q not found", full)
+	}
+
+	raw := secret.Data
+	if nested, ok := raw["data"].(map[string]interface{}); ok {
+		raw = nested
+	}
+
+	out := make(map[string]string, len(raw))
+	for k, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value for key %q in vault secret %q is not a string", k, full)
+		}
+		out[k] = s
+	}
+	return out, nil
+}
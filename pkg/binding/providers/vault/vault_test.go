@@ -0,0 +1,84 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *vaultapi.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := vaultapi.DefaultConfig()
+	cfg.Address = server.URL
+	client, err := vaultapi.NewClient(cfg)
+	require.NoError(t, err)
+	client.SetToken("test-token")
+	return client
+}
+
+func TestReadUnwrapsKVv2(t *testing.T) {
+	// KV v2 is addressed by the caller embedding the "data" segment in
+	// mountPath (e.g. "secret/data/myapp"), per the mountPath= annotation
+	// convention; Read itself does no v1/v2 detection. What it does do is
+	// unwrap the extra "data" nesting KV v2 wraps the response body in,
+	// alongside "metadata" - that's what this test covers.
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/v1/secret/data/dbCredentials", req.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": map[string]interface{}{
+					"username": "AzureDiamond",
+					"password": "hunter2",
+				},
+				"metadata": map[string]interface{}{"version": 1},
+			},
+		})
+	})
+
+	r := &reader{client: client}
+	data, err := r.Read(context.Background(), "secret/data", "dbCredentials")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"username": "AzureDiamond",
+		"password": "hunter2",
+	}, data)
+}
+
+func TestReadKVv1IsUsedAsIs(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/v1/secret/dbCredentials", req.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"username": "AzureDiamond",
+				"password": "hunter2",
+			},
+		})
+	})
+
+	r := &reader{client: client}
+	data, err := r.Read(context.Background(), "secret", "dbCredentials")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"username": "AzureDiamond",
+		"password": "hunter2",
+	}, data)
+}
+
+func TestReadErrorsOnMissingSecret(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{}})
+	})
+
+	r := &reader{client: client}
+	_, err := r.Read(context.Background(), "secret", "missing")
+	require.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// objectType identifies what a mapFromDataFieldDefinition's path resolves
+// to: the name of an in-cluster Secret or ConfigMap, or a secret in an
+// external secret provider backend.
+type objectType string
+
+const (
+	secretObjectType    objectType = "Secret"
+	configMapObjectType objectType = "ConfigMap"
+
+	// The remaining object types are not backed by an in-cluster object at
+	// all: they name an external secret provider backend registered in
+	// pkg/binding/providers, and are resolved via an externalSecretReader
+	// rather than secretConfigMapReader.
+	vaultObjectType             objectType = "Vault"
+	awsSecretsManagerObjectType objectType = "AWSSecretsManager"
+	azureKeyVaultObjectType     objectType = "AzureKeyVault"
+	gcpSecretManagerObjectType  objectType = "GCPSecretManager"
+)
+
+// isExternal reports whether t names an external secret provider backend
+// rather than an in-cluster Secret or ConfigMap.
+func (t objectType) isExternal() bool {
+	switch t {
+	case vaultObjectType, awsSecretsManagerObjectType, azureKeyVaultObjectType, gcpSecretManagerObjectType:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	secretsGVR    = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+)
+
+// secretReader fetches the Data of a Secret, decoded to strings, given its
+// namespace and name.
+type secretReader func(namespace, name string) (map[string]string, error)
+
+// configMapReader fetches the Data of a ConfigMap given its namespace and
+// name.
+type configMapReader func(namespace, name string) (map[string]string, error)
+
+// secretsReader returns a secretReader backed by client.
+func secretsReader(client dynamic.Interface) secretReader {
+	return func(namespace, name string) (map[string]string, error) {
+		u, err := client.Resource(secretsGVR).Namespace(namespace).Get(context.TODO(), name, metaGetOptions)
+		if err != nil {
+			return nil, err
+		}
+		return stringDataFromUnstructured(u, "data", true)
+	}
+}
+
+// configMapsReader returns a configMapReader backed by client.
+func configMapsReader(client dynamic.Interface) configMapReader {
+	return func(namespace, name string) (map[string]string, error) {
+		u, err := client.Resource(configMapsGVR).Namespace(namespace).Get(context.TODO(), name, metaGetOptions)
+		if err != nil {
+			return nil, err
+		}
+		return stringDataFromUnstructured(u, "data", false)
+	}
+}
+
+// secretConfigMapReader knows how to read the Data field of either a Secret
+// or a ConfigMap, depending on the objectType it is asked for.
+type secretConfigMapReader struct {
+	secretReader    secretReader
+	configMapReader configMapReader
+}
+
+func (r *secretConfigMapReader) read(t objectType, namespace, name string) (map[string]string, error) {
+	switch t {
+	case secretObjectType:
+		return r.secretReader(namespace, name)
+	case configMapObjectType:
+		return r.configMapReader(namespace, name)
+	default:
+		return nil, fmt.Errorf("unsupported object type %q", t)
+	}
+}
+
+// mapFromDataFieldDefinition resolves its path to either the name of a
+// Secret/ConfigMap living in the source object's namespace, or (when
+// objectType names an external provider) the path of a secret in an
+// external store, reads its key/value data, and optionally projects a
+// single key out of it.
+type mapFromDataFieldDefinition struct {
+	*secretConfigMapReader
+	externalSecretReader externalSecretReader
+	objectType           objectType
+	// mountPath locates the external provider's mount/engine to read path
+	// from (e.g. a Vault KV mount); it is unused when objectType names an
+	// in-cluster Secret or ConfigMap.
+	mountPath   string
+	definition  definition
+	sourceValue string
+	outputName  string
+}
+
+func (d *mapFromDataFieldDefinition) Apply(obj *unstructured.Unstructured) (Value, error) {
+	resolved, err := d.definition.resolve(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := stringify(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, _, err := unstructured.NestedString(obj.Object, "metadata", "namespace")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := d.readData(namespace, path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = d.applyTransformsToData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.sourceValue == "" {
+		return &value{v: data}, nil
+	}
+
+	v, ok := data[d.sourceValue]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in %s %s/%s", d.sourceValue, d.objectType, namespace, path)
+	}
+
+	outputName := d.outputName
+	if outputName == "" {
+		outputName = d.sourceValue
+	}
+
+	return &value{v: map[string]string{
+		outputName: v,
+	}}, nil
+}
+
+// applyTransformsToData runs d's transforms over each value of data
+// independently, rather than over data as a whole. data's entries are
+// unrelated Secret/ConfigMap/external-store keys rather than fields of a
+// single structured value, so transforms such as base64decode or jsonparse
+// - which expect a single string - are applied per key instead of being
+// handed the whole map.
+func (d *mapFromDataFieldDefinition) applyTransformsToData(data map[string]string) (map[string]string, error) {
+	if len(d.definition.transforms) == 0 {
+		return data, nil
+	}
+
+	out := make(map[string]string, len(data))
+	for k, v := range data {
+		transformed, err := d.definition.applyTransforms(v)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k, err)
+		}
+		s, ok := transformed.(string)
+		if !ok {
+			return nil, fmt.Errorf("key %q: transform must produce a string, got %T", k, transformed)
+		}
+		out[k] = s
+	}
+	return out, nil
+}
+
+// readData fetches the key/value data d's path resolved to, either from an
+// in-cluster Secret/ConfigMap or from the configured external provider.
+func (d *mapFromDataFieldDefinition) readData(namespace, path string) (map[string]string, error) {
+	if d.objectType.isExternal() {
+		return d.externalSecretReader.Read(context.TODO(), d.mountPath, path)
+	}
+	return d.read(d.objectType, namespace, path)
+}
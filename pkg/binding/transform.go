@@ -0,0 +1,70 @@
+package binding
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Transform is a post-processing step applied to a Definition's resolved
+// value before it is projected into the binding output.
+type Transform interface {
+	// Apply transforms v, returning the replacement value.
+	Apply(v interface{}) (interface{}, error)
+}
+
+// TransformFactory builds a Transform from the argument following its name
+// in a transform= clause (e.g. the "<pattern>:<replacement>" in
+// "regexp:<pattern>:<replacement>"), which is empty for transforms that
+// take none.
+type TransformFactory func(arg string) (Transform, error)
+
+var transformRegistry = map[string]TransformFactory{}
+
+// RegisterTransform makes a TransformFactory available under name, for use
+// in a transform= annotation clause. It is meant to be called from the
+// init function of a package implementing a Transform.
+func RegisterTransform(name string, factory TransformFactory) {
+	transformRegistry[name] = factory
+}
+
+// parseTransforms parses the value of a transform= annotation clause into
+// a chain of Transforms. Multiple transforms are chained by separating
+// them with "|", e.g. "base64decode|jsonparse".
+func parseTransforms(spec string) ([]Transform, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	specs := strings.Split(spec, "|")
+	transforms := make([]Transform, 0, len(specs))
+	for _, s := range specs {
+		t, err := parseTransform(s)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, t)
+	}
+	return transforms, nil
+}
+
+func parseTransform(spec string) (Transform, error) {
+	name, arg, _ := strings.Cut(spec, ":")
+	factory, ok := transformRegistry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown transform %q", name)
+	}
+	return factory(arg)
+}
+
+// applyTransforms runs v through transforms in order, stopping at the
+// first error.
+func applyTransforms(v interface{}, transforms []Transform) (interface{}, error) {
+	for _, t := range transforms {
+		var err error
+		v, err = t.Apply(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,120 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+func init() {
+	RegisterTransform("template", newTemplateTransform)
+	RegisterTransform("base64decode", newBase64DecodeTransform)
+	RegisterTransform("base64encode", newBase64EncodeTransform)
+	RegisterTransform("jsonparse", newJSONParseTransform)
+	RegisterTransform("regexp", newRegexpTransform)
+}
+
+// templateTransform renders a Go text/template, with the value it is
+// applied to available to the template as ".".
+type templateTransform struct {
+	tmpl *template.Template
+}
+
+func newTemplateTransform(arg string) (Transform, error) {
+	text := strings.TrimSuffix(strings.TrimPrefix(arg, `"`), `"`)
+	tmpl, err := template.New("transform").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("template transform: %w", err)
+	}
+	return &templateTransform{tmpl: tmpl}, nil
+}
+
+func (t *templateTransform) Apply(v interface{}) (interface{}, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, v); err != nil {
+		return nil, fmt.Errorf("template transform: %w", err)
+	}
+	return buf.String(), nil
+}
+
+type base64DecodeTransform struct{}
+
+func newBase64DecodeTransform(string) (Transform, error) {
+	return base64DecodeTransform{}, nil
+}
+
+func (base64DecodeTransform) Apply(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("base64decode transform: value is a %T, not a string", v)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("base64decode transform: %w", err)
+	}
+	return string(decoded), nil
+}
+
+type base64EncodeTransform struct{}
+
+func newBase64EncodeTransform(string) (Transform, error) {
+	return base64EncodeTransform{}, nil
+}
+
+func (base64EncodeTransform) Apply(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("base64encode transform: value is a %T, not a string", v)
+	}
+	return base64.StdEncoding.EncodeToString([]byte(s)), nil
+}
+
+// jsonParseTransform parses a string value as JSON, replacing it with the
+// decoded structure.
+type jsonParseTransform struct{}
+
+func newJSONParseTransform(string) (Transform, error) {
+	return jsonParseTransform{}, nil
+}
+
+func (jsonParseTransform) Apply(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("jsonparse transform: value is a %T, not a string", v)
+	}
+	var out interface{}
+	if err := json.Unmarshal([]byte(s), &out); err != nil {
+		return nil, fmt.Errorf("jsonparse transform: %w", err)
+	}
+	return out, nil
+}
+
+// regexpTransform applies regexp.ReplaceAllString to a string value.
+type regexpTransform struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+func newRegexpTransform(arg string) (Transform, error) {
+	pattern, replacement, ok := strings.Cut(arg, ":")
+	if !ok {
+		return nil, fmt.Errorf("regexp transform: expected <pattern>:<replacement>, got %q", arg)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("regexp transform: %w", err)
+	}
+	return &regexpTransform{re: re, replacement: replacement}, nil
+}
+
+func (t *regexpTransform) Apply(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("regexp transform: value is a %T, not a string", v)
+	}
+	return t.re.ReplaceAllString(s, t.replacement), nil
+}
@@ -0,0 +1,42 @@
+package binding
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var metaGetOptions = metav1.GetOptions{}
+
+// stringDataFromUnstructured reads field (e.g. "data") off u as a
+// map[string]string. Secret data is base64-encoded on the wire, so
+// base64Decode should be true when reading a Secret and false for a
+// ConfigMap.
+func stringDataFromUnstructured(u *unstructured.Unstructured, field string, base64Decode bool) (map[string]string, error) {
+	raw, found, err := unstructured.NestedMap(u.Object, field)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return map[string]string{}, nil
+	}
+
+	out := make(map[string]string, len(raw))
+	for k, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value for key %q in %q is not a string", k, field)
+		}
+		if base64Decode {
+			decoded, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode value for key %q in %q: %w", k, field, err)
+			}
+			s = string(decoded)
+		}
+		out[k] = s
+	}
+	return out, nil
+}
@@ -0,0 +1,19 @@
+package binding
+
+// Value is the result of applying a Definition to a source object. It wraps
+// whatever shape the Definition produced (a scalar, a map, or a slice) so
+// that callers don't need to know which concrete Definition produced it.
+type Value interface {
+	// Get returns the underlying value produced by a Definition.
+	Get() interface{}
+}
+
+// value is the default Value implementation; it holds the produced value
+// verbatim.
+type value struct {
+	v interface{}
+}
+
+func (v *value) Get() interface{} {
+	return v.v
+}
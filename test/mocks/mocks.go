@@ -0,0 +1,106 @@
+// Package mocks provides lightweight fakes for exercising code that talks
+// to the Kubernetes API via a dynamic.Interface, without a real cluster.
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var scheme = runtime.NewScheme()
+
+var (
+	secretsGVR    = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	secretListKind    = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	configMapListKind = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+)
+
+func init() {
+	_ = corev1.AddToScheme(scheme)
+}
+
+// Fake bundles a fake dynamic client together with the namespace tests run
+// against, plus helpers to seed commonly-needed objects into it.
+type Fake struct {
+	t         *testing.T
+	namespace string
+	client    dynamic.Interface
+}
+
+// NewFake returns a Fake wired to an empty fake dynamic client, scoped to
+// namespace.
+func NewFake(t *testing.T, namespace string) *Fake {
+	return &Fake{
+		t:         t,
+		namespace: namespace,
+		client: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+			secretListKind:    "SecretList",
+			configMapListKind: "ConfigMapList",
+		}),
+	}
+}
+
+// FakeDynClient returns the underlying fake dynamic.Interface.
+func (f *Fake) FakeDynClient() dynamic.Interface {
+	return f.client
+}
+
+// AddMockedUnstructuredSecret registers a Secret named name, with
+// "username"/"password" keys, in the fake dynamic client's namespace.
+func (f *Fake) AddMockedUnstructuredSecret(name string) {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: f.namespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("user"),
+			"password": []byte("password"),
+		},
+	}
+	f.addObject(secretsGVR, secret)
+}
+
+// AddMockedUnstructuredConfigMap registers a ConfigMap named name, with
+// "username"/"password" keys, in the fake dynamic client's namespace.
+func (f *Fake) AddMockedUnstructuredConfigMap(name string) {
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: f.namespace,
+		},
+		Data: map[string]string{
+			"username": "user",
+			"password": "password",
+		},
+	}
+	f.addObject(configMapsGVR, configMap)
+}
+
+func (f *Fake) addObject(gvr schema.GroupVersionResource, obj runtime.Object) {
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(f.t, err)
+
+	u := &unstructured.Unstructured{Object: raw}
+	_, err = f.client.Resource(gvr).Namespace(f.namespace).Create(context.TODO(), u, metav1.CreateOptions{})
+	require.NoError(f.t, err)
+}